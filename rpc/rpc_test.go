@@ -0,0 +1,91 @@
+package rpc_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"net/rpc"
+	"testing"
+
+	"github.com/nathanielc/gdvariant"
+	gdrpc "github.com/nathanielc/gdvariant/rpc"
+)
+
+type Args struct {
+	A, B int32
+}
+
+type Reply struct {
+	Sum int32
+}
+
+type Arith int
+
+func (t *Arith) Add(args *Args, reply *Reply) error {
+	reply.Sum = args.A + args.B
+	return nil
+}
+
+func TestClientServerCodec(t *testing.T) {
+	clientConn, serverConn := net.Pipe()
+
+	server := rpc.NewServer()
+	if err := server.Register(new(Arith)); err != nil {
+		t.Fatal(err)
+	}
+	go server.ServeCodec(gdrpc.NewServerCodec(serverConn))
+
+	client := rpc.NewClientWithCodec(gdrpc.NewClientCodec(clientConn))
+	defer client.Close()
+
+	var reply Reply
+	if err := client.Call("Arith.Add", &Args{A: 3, B: 4}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if reply.Sum != 7 {
+		t.Errorf("got sum %d, exp 7", reply.Sum)
+	}
+}
+
+// TestServerCodec_PositionalArgs drives a call shaped like Godot's common
+// multi-argument convention - [senderID, methodName, x, y, z] rather than
+// a single aggregate dictionary - through ReadRequestHeader/ReadRequestBody
+// directly, since net/rpc's own Client.Call only ever sends one args
+// value.
+func TestServerCodec_PositionalArgs(t *testing.T) {
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+
+	go func() {
+		call := []interface{}{gdvariant.Integer(1), "update_position", float32(1.5), float32(2.5), float32(3.5)}
+		var payload bytes.Buffer
+		if err := gdvariant.NewEncoder(&payload).Encode(call); err != nil {
+			panic(err)
+		}
+		var size [4]byte
+		binary.LittleEndian.PutUint32(size[:], uint32(payload.Len()))
+		clientConn.Write(size[:])
+		clientConn.Write(payload.Bytes())
+	}()
+
+	codec := gdrpc.NewServerCodec(serverConn)
+
+	var req rpc.Request
+	if err := codec.ReadRequestHeader(&req); err != nil {
+		t.Fatal(err)
+	}
+	if req.ServiceMethod != "update_position" {
+		t.Errorf("got ServiceMethod %q, exp update_position", req.ServiceMethod)
+	}
+
+	type PosArgs struct {
+		X, Y, Z float32
+	}
+	var args PosArgs
+	if err := codec.ReadRequestBody(&args); err != nil {
+		t.Fatal(err)
+	}
+	if exp := (PosArgs{X: 1.5, Y: 2.5, Z: 3.5}); args != exp {
+		t.Errorf("got args %+v, exp %+v", args, exp)
+	}
+}
@@ -38,6 +38,15 @@ const (
 	ColorArrayType          = 28
 )
 
+// EncodeFlag64 is set on the high bits of the type header when an
+// Integer or Float payload is encoded as 8 bytes (int64/double)
+// instead of Godot's default 4-byte (int32/float) width.
+const EncodeFlag64 uint32 = 1 << 16
+
+// TypeMask isolates the variant type from any encode flags packed into
+// the rest of the header word.
+const TypeMask uint32 = 0xFF
+
 type VariantMarshaler interface {
 	MarshalVariant() ([]byte, error)
 }
@@ -96,10 +105,62 @@ func (f *Float) UnmarshalVariant(data []byte) error {
 	return nil
 }
 
+// Integer64 is the 8-byte variant encoding of a Godot `int`/`long`,
+// signalled by EncodeFlag64 on the type header.
+type Integer64 int64
+
+var integer64Type = reflect.TypeOf(Integer64(0))
+
+func (i Integer64) MarshalVariant() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := WriteHeader(&buf, IntegerType|EncodeFlag64); err != nil {
+		return nil, err
+	}
+	if err := WriteInt64(&buf, int64(i)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (i *Integer64) UnmarshalVariant(data []byte) error {
+	if len(data) < 8 {
+		return io.ErrUnexpectedEOF
+	}
+	*i = Integer64(Int64FromBytes(data[0:8]))
+	return nil
+}
+
+// Float64 is the 8-byte variant encoding of a Godot `float`/`double`,
+// signalled by EncodeFlag64 on the type header.
+type Float64 float64
+
+var float64Type = reflect.TypeOf(Float64(0))
+
+func (f Float64) MarshalVariant() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := WriteHeader(&buf, FloatType|EncodeFlag64); err != nil {
+		return nil, err
+	}
+	if err := WriteFloat64(&buf, float64(f)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (f *Float64) UnmarshalVariant(data []byte) error {
+	if len(data) < 8 {
+		return io.ErrUnexpectedEOF
+	}
+	*f = Float64(Float64FromBytes(data[0:8]))
+	return nil
+}
+
 type Vector3 struct {
 	X, Y, Z float32
 }
 
+var vector3Type = reflect.TypeOf(Vector3{})
+
 func (v Vector3) MarshalVariant() ([]byte, error) {
 	var buf bytes.Buffer
 	if err := WriteHeader(&buf, Vector3Type); err != nil {
@@ -126,3 +187,275 @@ func (v *Vector3) UnmarshalVariant(data []byte) error {
 	v.Z = Float32FromBytes(data[8:12])
 	return nil
 }
+
+type Bool bool
+
+var boolType = reflect.TypeOf(Bool(false))
+
+func (b Bool) MarshalVariant() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := WriteHeader(&buf, BoolType); err != nil {
+		return nil, err
+	}
+	v := int32(0)
+	if b {
+		v = 1
+	}
+	if err := WriteInt32(&buf, v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (b *Bool) UnmarshalVariant(data []byte) error {
+	if len(data) < 4 {
+		return io.ErrUnexpectedEOF
+	}
+	*b = Int32FromBytes(data[0:4]) != 0
+	return nil
+}
+
+type Vector2 struct {
+	X, Y float32
+}
+
+var vector2Type = reflect.TypeOf(Vector2{})
+
+func (v Vector2) MarshalVariant() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := WriteHeader(&buf, Vector2Type); err != nil {
+		return nil, err
+	}
+	if err := WriteFloat32(&buf, v.X); err != nil {
+		return nil, err
+	}
+	if err := WriteFloat32(&buf, v.Y); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (v *Vector2) UnmarshalVariant(data []byte) error {
+	if len(data) < 8 {
+		return io.ErrUnexpectedEOF
+	}
+	v.X = Float32FromBytes(data[0:4])
+	v.Y = Float32FromBytes(data[4:8])
+	return nil
+}
+
+type Color struct {
+	R, G, B, A float32
+}
+
+var colorType = reflect.TypeOf(Color{})
+
+func (c Color) MarshalVariant() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := WriteHeader(&buf, ColorType); err != nil {
+		return nil, err
+	}
+	for _, f := range [4]float32{c.R, c.G, c.B, c.A} {
+		if err := WriteFloat32(&buf, f); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func (c *Color) UnmarshalVariant(data []byte) error {
+	if len(data) < 16 {
+		return io.ErrUnexpectedEOF
+	}
+	c.R = Float32FromBytes(data[0:4])
+	c.G = Float32FromBytes(data[4:8])
+	c.B = Float32FromBytes(data[8:12])
+	c.A = Float32FromBytes(data[12:16])
+	return nil
+}
+
+type Quat struct {
+	X, Y, Z, W float32
+}
+
+func (q Quat) MarshalVariant() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := WriteHeader(&buf, QuaternionType); err != nil {
+		return nil, err
+	}
+	for _, f := range [4]float32{q.X, q.Y, q.Z, q.W} {
+		if err := WriteFloat32(&buf, f); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func (q *Quat) UnmarshalVariant(data []byte) error {
+	if len(data) < 16 {
+		return io.ErrUnexpectedEOF
+	}
+	q.X = Float32FromBytes(data[0:4])
+	q.Y = Float32FromBytes(data[4:8])
+	q.Z = Float32FromBytes(data[8:12])
+	q.W = Float32FromBytes(data[12:16])
+	return nil
+}
+
+type Plane struct {
+	Normal Vector3
+	D      float32
+}
+
+func (p Plane) MarshalVariant() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := WriteHeader(&buf, PlaneType); err != nil {
+		return nil, err
+	}
+	for _, f := range [3]float32{p.Normal.X, p.Normal.Y, p.Normal.Z} {
+		if err := WriteFloat32(&buf, f); err != nil {
+			return nil, err
+		}
+	}
+	if err := WriteFloat32(&buf, p.D); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (p *Plane) UnmarshalVariant(data []byte) error {
+	if len(data) < 16 {
+		return io.ErrUnexpectedEOF
+	}
+	p.Normal.X = Float32FromBytes(data[0:4])
+	p.Normal.Y = Float32FromBytes(data[4:8])
+	p.Normal.Z = Float32FromBytes(data[8:12])
+	p.D = Float32FromBytes(data[12:16])
+	return nil
+}
+
+type AABB struct {
+	Position, Size Vector3
+}
+
+func (a AABB) MarshalVariant() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := WriteHeader(&buf, AabbType); err != nil {
+		return nil, err
+	}
+	for _, v := range [2]Vector3{a.Position, a.Size} {
+		for _, f := range [3]float32{v.X, v.Y, v.Z} {
+			if err := WriteFloat32(&buf, f); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func (a *AABB) UnmarshalVariant(data []byte) error {
+	if len(data) < 24 {
+		return io.ErrUnexpectedEOF
+	}
+	if err := a.Position.UnmarshalVariant(data[0:12]); err != nil {
+		return err
+	}
+	return a.Size.UnmarshalVariant(data[12:24])
+}
+
+// Basis holds the 3 row Vector3s of a Godot Basis (3x3 matrix).
+type Basis struct {
+	Elements [3]Vector3
+}
+
+func (b Basis) MarshalVariant() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := WriteHeader(&buf, Matrix3x3Type); err != nil {
+		return nil, err
+	}
+	for _, v := range b.Elements {
+		for _, f := range [3]float32{v.X, v.Y, v.Z} {
+			if err := WriteFloat32(&buf, f); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func (b *Basis) UnmarshalVariant(data []byte) error {
+	if len(data) < 36 {
+		return io.ErrUnexpectedEOF
+	}
+	for i := range b.Elements {
+		if err := b.Elements[i].UnmarshalVariant(data[i*12 : i*12+12]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type Transform struct {
+	Basis  Basis
+	Origin Vector3
+}
+
+func (t Transform) MarshalVariant() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := WriteHeader(&buf, TransformType); err != nil {
+		return nil, err
+	}
+	for _, v := range t.Basis.Elements {
+		for _, f := range [3]float32{v.X, v.Y, v.Z} {
+			if err := WriteFloat32(&buf, f); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, f := range [3]float32{t.Origin.X, t.Origin.Y, t.Origin.Z} {
+		if err := WriteFloat32(&buf, f); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func (t *Transform) UnmarshalVariant(data []byte) error {
+	if len(data) < 48 {
+		return io.ErrUnexpectedEOF
+	}
+	if err := t.Basis.UnmarshalVariant(data[0:36]); err != nil {
+		return err
+	}
+	return t.Origin.UnmarshalVariant(data[36:48])
+}
+
+// NodePath is a Godot path to a Node, e.g. "../Player/Sprite". It is
+// wire-compatible with a String but carries its own type tag.
+type NodePath string
+
+func (p NodePath) MarshalVariant() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := WriteHeader(&buf, NodePathType); err != nil {
+		return nil, err
+	}
+	if err := WriteUint32(&buf, uint32(len(p))); err != nil {
+		return nil, err
+	}
+	if _, err := buf.WriteString(string(p)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (p *NodePath) UnmarshalVariant(data []byte) error {
+	if len(data) < 4 {
+		return io.ErrUnexpectedEOF
+	}
+	size := Int32FromBytes(data[0:4])
+	if len(data) < 4+int(size) {
+		return io.ErrUnexpectedEOF
+	}
+	*p = NodePath(data[4 : 4+size])
+	return nil
+}
@@ -0,0 +1,270 @@
+package gdvariant
+
+import "reflect"
+
+// Kind identifies the dynamic type held by a Value.
+type Kind int
+
+const (
+	KindInvalid Kind = iota
+	KindNull
+	KindBool
+	KindInt
+	KindFloat
+	KindString
+	KindVector2
+	KindVector3
+	KindColor
+	KindQuat
+	KindPlane
+	KindAABB
+	KindBasis
+	KindTransform
+	KindNodePath
+	KindBytes
+	KindArray
+	KindDict
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindNull:
+		return "Null"
+	case KindBool:
+		return "Bool"
+	case KindInt:
+		return "Int"
+	case KindFloat:
+		return "Float"
+	case KindString:
+		return "String"
+	case KindVector2:
+		return "Vector2"
+	case KindVector3:
+		return "Vector3"
+	case KindColor:
+		return "Color"
+	case KindQuat:
+		return "Quat"
+	case KindPlane:
+		return "Plane"
+	case KindAABB:
+		return "AABB"
+	case KindBasis:
+		return "Basis"
+	case KindTransform:
+		return "Transform"
+	case KindNodePath:
+		return "NodePath"
+	case KindBytes:
+		return "Bytes"
+	case KindArray:
+		return "Array"
+	case KindDict:
+		return "Dict"
+	default:
+		return "Invalid"
+	}
+}
+
+// Value wraps a decoded variant tree for schemaless traversal, the way
+// json-iterator's Any lets callers walk JSON without predeclared Go
+// structs. It is returned by Decoder.DecodeValue and is the type tooling
+// (editors, inspectors, migration scripts) should use to inspect a
+// Godot save file without a matching struct.
+type Value struct {
+	raw interface{}
+}
+
+// notFound marks the result of a Get that couldn't resolve its path, so
+// it reports KindInvalid rather than being confused with a decoded
+// variant Null (a nil raw value).
+type notFound struct{}
+
+// Kind reports the dynamic type stored in v.
+func (v Value) Kind() Kind {
+	switch v.raw.(type) {
+	case notFound:
+		return KindInvalid
+	case nil:
+		return KindNull
+	case Bool:
+		return KindBool
+	case Integer, Integer64:
+		return KindInt
+	case Float, Float64:
+		return KindFloat
+	case string:
+		return KindString
+	case Vector2:
+		return KindVector2
+	case Vector3:
+		return KindVector3
+	case Color:
+		return KindColor
+	case Quat:
+		return KindQuat
+	case Plane:
+		return KindPlane
+	case AABB:
+		return KindAABB
+	case Basis:
+		return KindBasis
+	case Transform:
+		return KindTransform
+	case NodePath:
+		return KindNodePath
+	case []byte:
+		return KindBytes
+	case []interface{}, []int32, []float32, []string, []Vector2, []Vector3, []Color:
+		return KindArray
+	case map[string]interface{}:
+		return KindDict
+	default:
+		return KindInvalid
+	}
+}
+
+// Get walks path through nested dictionaries (string segments) and
+// arrays (int segments), returning a Value with Kind() == KindInvalid
+// if any segment is missing or the wrong kind.
+func (v Value) Get(path ...interface{}) Value {
+	cur := v.raw
+	for _, seg := range path {
+		switch key := seg.(type) {
+		case string:
+			m, ok := cur.(map[string]interface{})
+			if !ok {
+				return Value{raw: notFound{}}
+			}
+			cur, ok = m[key]
+			if !ok {
+				return Value{raw: notFound{}}
+			}
+		case int:
+			rv := reflect.ValueOf(cur)
+			if !rv.IsValid() || rv.Kind() != reflect.Slice || key < 0 || key >= rv.Len() {
+				return Value{raw: notFound{}}
+			}
+			cur = rv.Index(key).Interface()
+		default:
+			return Value{raw: notFound{}}
+		}
+	}
+	return Value{raw: cur}
+}
+
+// Int returns v as an int64, converting from any numeric Kind. It
+// returns 0 if v does not hold a number.
+func (v Value) Int() int64 {
+	switch n := v.raw.(type) {
+	case Integer:
+		return int64(n)
+	case Integer64:
+		return int64(n)
+	case Float:
+		return int64(n)
+	case Float64:
+		return int64(n)
+	default:
+		return 0
+	}
+}
+
+// Float returns v as a float64, converting from any numeric Kind. It
+// returns 0 if v does not hold a number.
+func (v Value) Float() float64 {
+	switch n := v.raw.(type) {
+	case Float:
+		return float64(n)
+	case Float64:
+		return float64(n)
+	case Integer:
+		return float64(n)
+	case Integer64:
+		return float64(n)
+	default:
+		return 0
+	}
+}
+
+// Str returns v as a string. It returns "" if v does not hold a String
+// or NodePath.
+func (v Value) Str() string {
+	switch s := v.raw.(type) {
+	case string:
+		return s
+	case NodePath:
+		return string(s)
+	default:
+		return ""
+	}
+}
+
+// Vec3 returns v as a Vector3. It returns the zero Vector3 if v does
+// not hold one.
+func (v Value) Vec3() Vector3 {
+	vec, _ := v.raw.(Vector3)
+	return vec
+}
+
+// Len returns the number of elements in an Array, key/value pairs in a
+// Dict, or bytes in a Bytes, String or NodePath value. It returns 0 for
+// any other Kind.
+func (v Value) Len() int {
+	rv := reflect.ValueOf(v.raw)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map, reflect.String:
+		return rv.Len()
+	default:
+		return 0
+	}
+}
+
+// Iter returns a stateful iterator function over an Array or Dict. Each
+// call returns the next (key, value, ok); key is an int index for an
+// Array or a string key for a Dict. Iteration ends when ok is false.
+func (v Value) Iter() func() (interface{}, Value, bool) {
+	if m, ok := v.raw.(map[string]interface{}); ok {
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		i := 0
+		return func() (interface{}, Value, bool) {
+			if i >= len(keys) {
+				return nil, Value{}, false
+			}
+			k := keys[i]
+			i++
+			return k, Value{raw: m[k]}, true
+		}
+	}
+
+	rv := reflect.ValueOf(v.raw)
+	if !rv.IsValid() || rv.Kind() != reflect.Slice {
+		return func() (interface{}, Value, bool) {
+			return nil, Value{}, false
+		}
+	}
+	i := 0
+	return func() (interface{}, Value, bool) {
+		if i >= rv.Len() {
+			return nil, Value{}, false
+		}
+		idx := i
+		i++
+		return idx, Value{raw: rv.Index(idx).Interface()}, true
+	}
+}
+
+// DecodeValue decodes the next variant on the wire into a Value, for
+// callers that want to traverse a dictionary or array without
+// predeclaring a matching Go struct.
+func (d *Decoder) DecodeValue() (Value, error) {
+	o, err := decodeObj(d.r)
+	if err != nil {
+		return Value{}, err
+	}
+	return Value{raw: o}, nil
+}
@@ -0,0 +1,88 @@
+package gdvariant_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/nathanielc/gdvariant"
+)
+
+func TestValue_Traversal(t *testing.T) {
+	type item struct {
+		Name string
+	}
+	type save struct {
+		Player   string
+		Score    int32
+		Position gdvariant.Vector3
+		Items    []item
+	}
+
+	exp := save{
+		Player:   "Ripley",
+		Score:    42,
+		Position: gdvariant.Vector3{X: 1, Y: 2, Z: 3},
+		Items: []item{
+			{Name: "pulse rifle"},
+			{Name: "flamethrower"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := gdvariant.NewEncoder(&buf).Encode(exp); err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := gdvariant.NewDecoder(&buf).DecodeValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if v.Kind() != gdvariant.KindDict {
+		t.Fatalf("expected KindDict, got %s", v.Kind())
+	}
+	if got := v.Get("Player").Str(); got != exp.Player {
+		t.Errorf("got Player %q, exp %q", got, exp.Player)
+	}
+	if got := v.Get("Score").Int(); got != int64(exp.Score) {
+		t.Errorf("got Score %d, exp %d", got, exp.Score)
+	}
+	if got := v.Get("Position").Vec3(); got != exp.Position {
+		t.Errorf("got Position %+v, exp %+v", got, exp.Position)
+	}
+
+	items := v.Get("Items")
+	if items.Kind() != gdvariant.KindArray {
+		t.Fatalf("expected KindArray, got %s", items.Kind())
+	}
+	if got, exp := items.Len(), len(exp.Items); got != exp {
+		t.Errorf("got %d items, exp %d", got, exp)
+	}
+	if got := items.Get(1).Get("Name").Str(); got != exp.Items[1].Name {
+		t.Errorf("got Items[1].Name %q, exp %q", got, exp.Items[1].Name)
+	}
+
+	next := items.Iter()
+	count := 0
+	for {
+		key, el, ok := next()
+		if !ok {
+			break
+		}
+		idx, isInt := key.(int)
+		if !isInt || idx != count {
+			t.Errorf("expected key %d, got %v", count, key)
+		}
+		if got := el.Get("Name").Str(); got != exp.Items[count].Name {
+			t.Errorf("got item %d Name %q, exp %q", count, got, exp.Items[count].Name)
+		}
+		count++
+	}
+	if count != len(exp.Items) {
+		t.Errorf("iterated %d items, exp %d", count, len(exp.Items))
+	}
+
+	if missing := v.Get("DoesNotExist"); missing.Kind() != gdvariant.KindInvalid {
+		t.Errorf("expected KindInvalid for missing key, got %s", missing.Kind())
+	}
+}
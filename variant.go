@@ -1,28 +1,52 @@
 package gdvariant
 
 import (
+	"bytes"
 	"encoding/binary"
 	"fmt"
 	"io"
 	"math"
 	"reflect"
+	"strings"
 
 	"github.com/mitchellh/mapstructure"
 )
 
+// structTagName is the struct tag both the Encoder and Decoder consult
+// for a field's dictionary key, e.g. `gdvariant:"player_name,omitempty"`.
+const structTagName = "gdvariant"
+
 func readHeader(r io.Reader) (header uint32, err error) {
 	err = binary.Read(r, binary.LittleEndian, &header)
 	return
 }
 
 type Decoder struct {
-	r io.Reader
+	r      io.Reader
+	strict bool
 }
 
-func NewDecoder(r io.Reader) *Decoder {
-	return &Decoder{
+// DecoderOption configures optional Decoder behavior.
+type DecoderOption func(*Decoder)
+
+// Strict requires every dictionary key decoded from the wire to map to
+// a field on the destination struct, returning an error otherwise. Use
+// it to catch schema drift between a Go struct and the GDScript that
+// produced the dictionary.
+func Strict() DecoderOption {
+	return func(d *Decoder) {
+		d.strict = true
+	}
+}
+
+func NewDecoder(r io.Reader, opts ...DecoderOption) *Decoder {
+	d := &Decoder{
 		r: r,
 	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
 }
 
 func (d *Decoder) Decode(i interface{}) error {
@@ -30,39 +54,93 @@ func (d *Decoder) Decode(i interface{}) error {
 	if err != nil {
 		return err
 	}
-	return mapstructure.Decode(o, i)
+	dec, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		TagName:     structTagName,
+		ErrorUnused: d.strict,
+		Result:      i,
+	})
+	if err != nil {
+		return err
+	}
+	return dec.Decode(o)
 }
 
 func decodeObj(r io.Reader) (o interface{}, err error) {
-	typ, err := readHeader(r)
+	header, err := readHeader(r)
 	if err != nil {
 		return nil, err
 	}
+	typ := header & TypeMask
+	flags := header &^ TypeMask
 	switch typ {
-	case StringType:
-		o, err = decodeStr(r)
-	case IntegerType:
-		i := new(Integer)
+	case NullType:
+		o = nil
+	case BoolType:
+		b := new(Bool)
 		buf := make([]byte, 4)
 		if _, err := io.ReadAtLeast(r, buf, 4); err != nil {
 			return nil, err
 		}
-		if err := i.UnmarshalVariant(buf); err != nil {
+		if err := b.UnmarshalVariant(buf); err != nil {
 			return nil, err
 		}
-		o = *i
+		o = *b
+	case StringType:
+		o, err = decodeStr(r)
+	case IntegerType:
+		if flags&EncodeFlag64 != 0 {
+			i := new(Integer64)
+			buf := make([]byte, 8)
+			if _, err := io.ReadAtLeast(r, buf, 8); err != nil {
+				return nil, err
+			}
+			if err := i.UnmarshalVariant(buf); err != nil {
+				return nil, err
+			}
+			o = *i
+		} else {
+			i := new(Integer)
+			buf := make([]byte, 4)
+			if _, err := io.ReadAtLeast(r, buf, 4); err != nil {
+				return nil, err
+			}
+			if err := i.UnmarshalVariant(buf); err != nil {
+				return nil, err
+			}
+			o = *i
+		}
 	case FloatType:
-		f := new(Float)
-		buf := make([]byte, 4)
-		if _, err := io.ReadAtLeast(r, buf, 4); err != nil {
+		if flags&EncodeFlag64 != 0 {
+			f := new(Float64)
+			buf := make([]byte, 8)
+			if _, err := io.ReadAtLeast(r, buf, 8); err != nil {
+				return nil, err
+			}
+			if err := f.UnmarshalVariant(buf); err != nil {
+				return nil, err
+			}
+			o = *f
+		} else {
+			f := new(Float)
+			buf := make([]byte, 4)
+			if _, err := io.ReadAtLeast(r, buf, 4); err != nil {
+				return nil, err
+			}
+			if err := f.UnmarshalVariant(buf); err != nil {
+				return nil, err
+			}
+			o = *f
+		}
+	case Vector2Type:
+		v := new(Vector2)
+		buf := make([]byte, 8)
+		if _, err := io.ReadAtLeast(r, buf, 8); err != nil {
 			return nil, err
 		}
-		if err := f.UnmarshalVariant(buf); err != nil {
+		if err := v.UnmarshalVariant(buf); err != nil {
 			return nil, err
 		}
-		o = *f
-	case DictionaryType:
-		o, err = decodeDict(r)
+		o = *v
 	case Vector3Type:
 		v := new(Vector3)
 		buf := make([]byte, 12)
@@ -73,10 +151,100 @@ func decodeObj(r io.Reader) (o interface{}, err error) {
 			return nil, err
 		}
 		o = *v
+	case PlaneType:
+		p := new(Plane)
+		buf := make([]byte, 16)
+		if _, err := io.ReadAtLeast(r, buf, 16); err != nil {
+			return nil, err
+		}
+		if err := p.UnmarshalVariant(buf); err != nil {
+			return nil, err
+		}
+		o = *p
+	case QuaternionType:
+		q := new(Quat)
+		buf := make([]byte, 16)
+		if _, err := io.ReadAtLeast(r, buf, 16); err != nil {
+			return nil, err
+		}
+		if err := q.UnmarshalVariant(buf); err != nil {
+			return nil, err
+		}
+		o = *q
+	case AabbType:
+		a := new(AABB)
+		buf := make([]byte, 24)
+		if _, err := io.ReadAtLeast(r, buf, 24); err != nil {
+			return nil, err
+		}
+		if err := a.UnmarshalVariant(buf); err != nil {
+			return nil, err
+		}
+		o = *a
+	case Matrix3x3Type:
+		b := new(Basis)
+		buf := make([]byte, 36)
+		if _, err := io.ReadAtLeast(r, buf, 36); err != nil {
+			return nil, err
+		}
+		if err := b.UnmarshalVariant(buf); err != nil {
+			return nil, err
+		}
+		o = *b
+	case TransformType:
+		t := new(Transform)
+		buf := make([]byte, 48)
+		if _, err := io.ReadAtLeast(r, buf, 48); err != nil {
+			return nil, err
+		}
+		if err := t.UnmarshalVariant(buf); err != nil {
+			return nil, err
+		}
+		o = *t
+	case ColorType:
+		c := new(Color)
+		buf := make([]byte, 16)
+		if _, err := io.ReadAtLeast(r, buf, 16); err != nil {
+			return nil, err
+		}
+		if err := c.UnmarshalVariant(buf); err != nil {
+			return nil, err
+		}
+		o = *c
+	case NodePathType:
+		sizeBuf := make([]byte, 4)
+		if _, err := io.ReadAtLeast(r, sizeBuf, 4); err != nil {
+			return nil, err
+		}
+		size := int(Int32FromBytes(sizeBuf))
+		strBuf := make([]byte, size)
+		if _, err := io.ReadAtLeast(r, strBuf, size); err != nil {
+			return nil, err
+		}
+		if err := discardPadding(r, size); err != nil {
+			return nil, err
+		}
+		p := new(NodePath)
+		if err := p.UnmarshalVariant(append(sizeBuf, strBuf...)); err != nil {
+			return nil, err
+		}
+		o = *p
+	case DictionaryType:
+		o, err = decodeDict(r)
 	case IntegerArrayType:
 		o, err = decodeIntegerArray(r)
 	case FloatArrayType:
 		o, err = decodeFloatArray(r)
+	case ByteArrayType:
+		o, err = decodeByteArray(r)
+	case StringArrayType:
+		o, err = decodeStringArray(r)
+	case Vector2ArrayType:
+		o, err = decodeVector2Array(r)
+	case Vector3ArrayType:
+		o, err = decodeVector3Array(r)
+	case ColorArrayType:
+		o, err = decodeColorArray(r)
 	case ArrayType:
 		o, err = decodeGenericArray(r)
 	default:
@@ -146,6 +314,96 @@ func decodeFloatArray(r io.Reader) ([]float32, error) {
 	return a, nil
 }
 
+func decodeByteArray(r io.Reader) ([]byte, error) {
+	header, err := readHeader(r)
+	if err != nil {
+		return nil, err
+	}
+	size := int(header)
+	a := make([]byte, size)
+	if _, err := io.ReadAtLeast(r, a, size); err != nil {
+		return nil, err
+	}
+	if err := discardPadding(r, size); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func decodeStringArray(r io.Reader) ([]string, error) {
+	header, err := readHeader(r)
+	if err != nil {
+		return nil, err
+	}
+	size := int(header)
+	a := make([]string, size)
+	for i := range a {
+		s, err := decodeStr(r)
+		if err != nil {
+			return nil, err
+		}
+		a[i] = s
+	}
+	return a, nil
+}
+
+func decodeVector2Array(r io.Reader) ([]Vector2, error) {
+	header, err := readHeader(r)
+	if err != nil {
+		return nil, err
+	}
+	size := int(header)
+	a := make([]Vector2, size)
+	buf := make([]byte, 8)
+	for i := range a {
+		if _, err := io.ReadAtLeast(r, buf, 8); err != nil {
+			return nil, err
+		}
+		if err := a[i].UnmarshalVariant(buf); err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+func decodeVector3Array(r io.Reader) ([]Vector3, error) {
+	header, err := readHeader(r)
+	if err != nil {
+		return nil, err
+	}
+	size := int(header)
+	a := make([]Vector3, size)
+	buf := make([]byte, 12)
+	for i := range a {
+		if _, err := io.ReadAtLeast(r, buf, 12); err != nil {
+			return nil, err
+		}
+		if err := a[i].UnmarshalVariant(buf); err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+func decodeColorArray(r io.Reader) ([]Color, error) {
+	header, err := readHeader(r)
+	if err != nil {
+		return nil, err
+	}
+	size := int(header)
+	a := make([]Color, size)
+	buf := make([]byte, 16)
+	for i := range a {
+		if _, err := io.ReadAtLeast(r, buf, 16); err != nil {
+			return nil, err
+		}
+		if err := a[i].UnmarshalVariant(buf); err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
 func decodeGenericArray(r io.Reader) ([]interface{}, error) {
 	header, err := readHeader(r)
 	if err != nil {
@@ -213,6 +471,12 @@ func (e *Encoder) Encode(i interface{}) error {
 }
 
 func (e *Encoder) encodeObj(v reflect.Value) error {
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return e.writeHeader(NullType)
+	}
 
 	if v.Type().Implements(marshalerType) {
 		m := v.Interface().(VariantMarshaler)
@@ -224,13 +488,22 @@ func (e *Encoder) encodeObj(v reflect.Value) error {
 	}
 
 	switch k := v.Kind(); k {
-	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
-		reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+	case reflect.Bool:
+		b := v.Convert(boolType)
+		return e.encodeObj(b)
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Int8, reflect.Int16, reflect.Int32:
 		i := v.Convert(integerType)
 		return e.encodeObj(i)
-	case reflect.Float32, reflect.Float64:
+	case reflect.Uint64, reflect.Int64:
+		i := v.Convert(integer64Type)
+		return e.encodeObj(i)
+	case reflect.Float32:
 		f := v.Convert(floatType)
 		return e.encodeObj(f)
+	case reflect.Float64:
+		f := v.Convert(float64Type)
+		return e.encodeObj(f)
 	case reflect.String:
 		return e.encodeStr(v.String())
 	case reflect.Slice:
@@ -267,6 +540,13 @@ func (e *Encoder) encodeStr(s string) error {
 	if err := e.writeHeader(StringType); err != nil {
 		return err
 	}
+	return e.writeStrBody(s)
+}
+
+// writeStrBody writes a string's size-prefixed, padded body without a
+// leading type tag, for use both as a top-level String and as an
+// element of a StringArray (which carries no per-element type tags).
+func (e *Encoder) writeStrBody(s string) error {
 	size := uint32(len(s))
 	if err := e.writeHeader(size); err != nil {
 		return err
@@ -289,6 +569,18 @@ func (e *Encoder) writeDictHeader(size int) error {
 
 func (e *Encoder) encodeSlice(v reflect.Value) error {
 	et := v.Type().Elem()
+	switch et {
+	case reflect.TypeOf(byte(0)):
+		return e.encodeByteArray(v)
+	case reflect.TypeOf(""):
+		return e.encodeStringArray(v)
+	case vector2Type:
+		return e.encodeVector2Array(v)
+	case vector3Type:
+		return e.encodeVector3Array(v)
+	case colorType:
+		return e.encodeColorArray(v)
+	}
 	switch et.Kind() {
 	case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
 		return e.encodeUIntegerArray(v)
@@ -352,6 +644,97 @@ func (e *Encoder) encodeFloatArray(v reflect.Value) error {
 	return nil
 }
 
+func (e *Encoder) encodeByteArray(v reflect.Value) error {
+	if err := e.writeHeader(ByteArrayType); err != nil {
+		return err
+	}
+	n := v.Len()
+	if err := e.writeHeader(uint32(n)); err != nil {
+		return err
+	}
+	b := make([]byte, n)
+	reflect.Copy(reflect.ValueOf(b), v)
+	return e.writePadded(b)
+}
+
+func (e *Encoder) encodeStringArray(v reflect.Value) error {
+	if err := e.writeHeader(StringArrayType); err != nil {
+		return err
+	}
+	n := v.Len()
+	if err := e.writeHeader(uint32(n)); err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		if err := e.writeStrBody(v.Index(i).String()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *Encoder) encodeVector2Array(v reflect.Value) error {
+	if err := e.writeHeader(Vector2ArrayType); err != nil {
+		return err
+	}
+	n := v.Len()
+	if err := e.writeHeader(uint32(n)); err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		vec := v.Index(i).Interface().(Vector2)
+		if err := WriteFloat32(e.w, vec.X); err != nil {
+			return err
+		}
+		if err := WriteFloat32(e.w, vec.Y); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *Encoder) encodeVector3Array(v reflect.Value) error {
+	if err := e.writeHeader(Vector3ArrayType); err != nil {
+		return err
+	}
+	n := v.Len()
+	if err := e.writeHeader(uint32(n)); err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		vec := v.Index(i).Interface().(Vector3)
+		if err := WriteFloat32(e.w, vec.X); err != nil {
+			return err
+		}
+		if err := WriteFloat32(e.w, vec.Y); err != nil {
+			return err
+		}
+		if err := WriteFloat32(e.w, vec.Z); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *Encoder) encodeColorArray(v reflect.Value) error {
+	if err := e.writeHeader(ColorArrayType); err != nil {
+		return err
+	}
+	n := v.Len()
+	if err := e.writeHeader(uint32(n)); err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		c := v.Index(i).Interface().(Color)
+		for _, f := range [4]float32{c.R, c.G, c.B, c.A} {
+			if err := WriteFloat32(e.w, f); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (e *Encoder) encodeGenericArray(v reflect.Value) error {
 	if err := e.writeHeader(ArrayType); err != nil {
 		return err
@@ -371,20 +754,69 @@ func (e *Encoder) encodeGenericArray(v reflect.Value) error {
 	return nil
 }
 
-func (e *Encoder) encodeStruct(v reflect.Value) error {
-	n := v.NumField()
-	if err := e.writeDictHeader(n); err != nil {
-		return nil
-	}
+type structField struct {
+	name  string
+	value reflect.Value
+}
 
+// structFields resolves the gdvariant struct tags on v, skipping
+// unexported fields, fields tagged "-", and omitempty fields holding a
+// zero value.
+func structFields(v reflect.Value) []structField {
 	t := v.Type()
-	for i := 0; i < n; i++ {
+	fields := make([]structField, 0, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
 		f := t.Field(i)
-		if err := e.encodeStr(f.Name); err != nil {
-			return err
+		if f.PkgPath != "" {
+			// unexported field
+			continue
+		}
+		name, omitempty, omit := parseFieldTag(f)
+		if omit {
+			continue
 		}
 		value := v.Field(i)
-		if err := e.encodeObj(value); err != nil {
+		if omitempty && value.IsZero() {
+			continue
+		}
+		fields = append(fields, structField{name: name, value: value})
+	}
+	return fields
+}
+
+// parseFieldTag parses the `gdvariant:"name,omitempty"` struct tag. A
+// bare "-" omits the field entirely; an empty name keeps f.Name.
+func parseFieldTag(f reflect.StructField) (name string, omitempty, omit bool) {
+	tag, ok := f.Tag.Lookup(structTagName)
+	if !ok {
+		return f.Name, false, false
+	}
+	parts := strings.Split(tag, ",")
+	if parts[0] == "-" && len(parts) == 1 {
+		return "", false, true
+	}
+	name = parts[0]
+	if name == "" {
+		name = f.Name
+	}
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitempty = true
+		}
+	}
+	return name, omitempty, false
+}
+
+func (e *Encoder) encodeStruct(v reflect.Value) error {
+	fields := structFields(v)
+	if err := e.writeDictHeader(len(fields)); err != nil {
+		return err
+	}
+	for _, f := range fields {
+		if err := e.encodeStr(f.name); err != nil {
+			return err
+		}
+		if err := e.encodeObj(f.value); err != nil {
 			return err
 		}
 	}
@@ -419,6 +851,15 @@ func Int32FromBytes(bytes []byte) int32 {
 	return int32(binary.LittleEndian.Uint32(bytes))
 }
 
+func Float64FromBytes(bytes []byte) float64 {
+	bits := binary.LittleEndian.Uint64(bytes)
+	return math.Float64frombits(bits)
+}
+
+func Int64FromBytes(bytes []byte) int64 {
+	return int64(binary.LittleEndian.Uint64(bytes))
+}
+
 func Float32ToBytes(float float32) []byte {
 	bits := math.Float32bits(float)
 	bytes := make([]byte, 4)
@@ -448,6 +889,15 @@ func WriteFloat32(w io.Writer, float float32) error {
 func WriteInt32(w io.Writer, i int32) error {
 	return binary.Write(w, binary.LittleEndian, i)
 }
+
+func WriteFloat64(w io.Writer, float float64) error {
+	bits := math.Float64bits(float)
+	return binary.Write(w, binary.LittleEndian, bits)
+}
+
+func WriteInt64(w io.Writer, i int64) error {
+	return binary.Write(w, binary.LittleEndian, i)
+}
 func WriteUint32(w io.Writer, i uint32) error {
 	return binary.Write(w, binary.LittleEndian, i)
 }
@@ -459,3 +909,56 @@ func WriteHeader(w io.Writer, header uint32) error {
 func ReadHeaderFromBytes(bytes []byte) uint32 {
 	return binary.LittleEndian.Uint32(bytes)
 }
+
+// PacketDecoder reads discrete variants off a stream framed the way
+// Godot's ENet/UDP transports deliver them: each variant is prefixed
+// with its size as a little-endian uint32. Use it directly on a
+// net.Conn so successive Decode calls don't interleave.
+type PacketDecoder struct {
+	r io.Reader
+}
+
+func NewPacketDecoder(r io.Reader) *PacketDecoder {
+	return &PacketDecoder{r: r}
+}
+
+func (p *PacketDecoder) Decode(i interface{}) error {
+	var size uint32
+	if err := binary.Read(p.r, binary.LittleEndian, &size); err != nil {
+		return err
+	}
+	buf := make([]byte, size)
+	if _, err := io.ReadFull(p.r, buf); err != nil {
+		return err
+	}
+	return DecodePacket(buf, i)
+}
+
+// PacketEncoder writes discrete variants to a stream using the same
+// size-prefixed framing as PacketDecoder expects.
+type PacketEncoder struct {
+	w io.Writer
+}
+
+func NewPacketEncoder(w io.Writer) *PacketEncoder {
+	return &PacketEncoder{w: w}
+}
+
+func (p *PacketEncoder) Encode(i interface{}) error {
+	var buf bytes.Buffer
+	if err := NewEncoder(&buf).Encode(i); err != nil {
+		return err
+	}
+	if err := WriteUint32(p.w, uint32(buf.Len())); err != nil {
+		return err
+	}
+	_, err := p.w.Write(buf.Bytes())
+	return err
+}
+
+// DecodePacket decodes a single variant already held in memory, such as
+// a whole WebSocket message, where the frame boundary is the message
+// boundary and there is no length prefix to skip.
+func DecodePacket(data []byte, i interface{}) error {
+	return NewDecoder(bytes.NewReader(data)).Decode(i)
+}
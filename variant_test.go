@@ -16,20 +16,34 @@ func TestVariant_EncodeDecode(t *testing.T) {
 		List    []uint32
 	}
 	type object struct {
-		FieldA   string
-		Strength gdvariant.Float
-		Mass     float32
-		Radius   float64
-		Count    int32
-		Index    gdvariant.Integer
-		Sub      subobject
+		FieldA    string
+		Strength  gdvariant.Float
+		Mass      float32
+		Radius    float64
+		Count     int32
+		Index     gdvariant.Integer
+		Sub       subobject
+		Enabled   bool
+		Offset    gdvariant.Vector2
+		Tint      gdvariant.Color
+		Rotation  gdvariant.Quat
+		Floor     gdvariant.Plane
+		Bounds    gdvariant.AABB
+		Orient    gdvariant.Basis
+		Placement gdvariant.Transform
+		Target    gdvariant.NodePath
+		Raw       []byte
+		Names     []string
+		Points    []gdvariant.Vector2
+		Corners   []gdvariant.Vector3
+		Palette   []gdvariant.Color
 	}
 
 	exp := object{
 		FieldA:   "field A",
 		Strength: -5,
 		Mass:     4,
-		Radius:   6 * 9,
+		Radius:   1.0 / 3.0,
 		Count:    9,
 		Index:    -3,
 		Sub: subobject{
@@ -45,6 +59,43 @@ func TestVariant_EncodeDecode(t *testing.T) {
 			},
 			List: []uint32{43, 215, 16},
 		},
+		Enabled: true,
+		Offset:  gdvariant.Vector2{X: 1, Y: 2},
+		Tint:    gdvariant.Color{R: 1, G: 0.5, B: 0.25, A: 1},
+		Rotation: gdvariant.Quat{
+			X: 0, Y: 0, Z: 0, W: 1,
+		},
+		Floor: gdvariant.Plane{
+			Normal: gdvariant.Vector3{X: 0, Y: 1, Z: 0},
+			D:      5,
+		},
+		Bounds: gdvariant.AABB{
+			Position: gdvariant.Vector3{X: 1, Y: 2, Z: 3},
+			Size:     gdvariant.Vector3{X: 4, Y: 5, Z: 6},
+		},
+		Orient: gdvariant.Basis{
+			Elements: [3]gdvariant.Vector3{
+				{X: 1, Y: 0, Z: 0},
+				{X: 0, Y: 1, Z: 0},
+				{X: 0, Y: 0, Z: 1},
+			},
+		},
+		Placement: gdvariant.Transform{
+			Basis: gdvariant.Basis{
+				Elements: [3]gdvariant.Vector3{
+					{X: 1, Y: 0, Z: 0},
+					{X: 0, Y: 1, Z: 0},
+					{X: 0, Y: 0, Z: 1},
+				},
+			},
+			Origin: gdvariant.Vector3{X: 9, Y: 8, Z: 7},
+		},
+		Target:  gdvariant.NodePath("../Player/Sprite"),
+		Raw:     []byte{1, 2, 3, 4, 5},
+		Names:   []string{"a", "bb", "ccc"},
+		Points:  []gdvariant.Vector2{{X: 1, Y: 2}, {X: 3, Y: 4}},
+		Corners: []gdvariant.Vector3{{X: 1, Y: 2, Z: 3}},
+		Palette: []gdvariant.Color{{R: 1, G: 1, B: 1, A: 1}},
 	}
 
 	var buf bytes.Buffer
@@ -61,3 +112,145 @@ func TestVariant_EncodeDecode(t *testing.T) {
 		t.Errorf("unexpected object:\ngot\n%+v\nexp\n%+v\n", got, exp)
 	}
 }
+
+func TestVariant_StructTags(t *testing.T) {
+	type object struct {
+		Name     string `gdvariant:"name"`
+		Internal string `gdvariant:"-"`
+		hidden   string
+		Nickname string `gdvariant:"nick,omitempty"`
+		Score    int32  `gdvariant:"score,omitempty"`
+	}
+
+	exp := object{
+		Name:     "Player",
+		Internal: "should not be encoded",
+		hidden:   "should not be encoded",
+		Nickname: "Tank",
+		Score:    0,
+	}
+
+	var buf bytes.Buffer
+	if err := gdvariant.NewEncoder(&buf).Encode(exp); err != nil {
+		t.Fatal(err)
+	}
+
+	var got map[string]interface{}
+	if err := gdvariant.NewDecoder(bytes.NewReader(buf.Bytes())).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := got["Internal"]; ok {
+		t.Errorf("expected Internal to be omitted, got %v", got)
+	}
+	if got["nick"] != "Tank" {
+		t.Errorf("expected non-empty omitempty field nick to decode, got %v", got)
+	}
+	if _, ok := got["score"]; ok {
+		t.Errorf("expected zero-valued omitempty field score to be omitted, got %v", got)
+	}
+	if got["name"] != "Player" {
+		t.Errorf("expected tagged field name to decode, got %v", got)
+	}
+
+	var strictOut object
+	err := gdvariant.NewDecoder(bytes.NewReader(buf.Bytes()), gdvariant.Strict()).Decode(&strictOut)
+	if err != nil {
+		t.Fatalf("strict decode of matching schema should succeed: %v", err)
+	}
+
+	type driftedObject struct {
+		Name string `gdvariant:"name"`
+	}
+	var drifted driftedObject
+	if err := gdvariant.NewDecoder(bytes.NewReader(buf.Bytes()), gdvariant.Strict()).Decode(&drifted); err == nil {
+		t.Error("expected strict decode to fail on unused dictionary key")
+	}
+}
+
+func TestPacketDecoder(t *testing.T) {
+	type message struct {
+		Kind string
+		Seq  int32
+	}
+
+	var stream bytes.Buffer
+	penc := gdvariant.NewPacketEncoder(&stream)
+	first := message{Kind: "hello", Seq: 1}
+	second := message{Kind: "world", Seq: 2}
+	if err := penc.Encode(first); err != nil {
+		t.Fatal(err)
+	}
+	if err := penc.Encode(second); err != nil {
+		t.Fatal(err)
+	}
+
+	pdec := gdvariant.NewPacketDecoder(&stream)
+	var gotFirst, gotSecond message
+	if err := pdec.Decode(&gotFirst); err != nil {
+		t.Fatal(err)
+	}
+	if err := pdec.Decode(&gotSecond); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(gotFirst, first) {
+		t.Errorf("got %+v, exp %+v", gotFirst, first)
+	}
+	if !reflect.DeepEqual(gotSecond, second) {
+		t.Errorf("got %+v, exp %+v", gotSecond, second)
+	}
+}
+
+func TestDecodePacket(t *testing.T) {
+	type message struct {
+		Kind string
+	}
+	exp := message{Kind: "whole-message"}
+
+	var buf bytes.Buffer
+	if err := gdvariant.NewEncoder(&buf).Encode(exp); err != nil {
+		t.Fatal(err)
+	}
+
+	var got message
+	if err := gdvariant.DecodePacket(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("got %+v, exp %+v", got, exp)
+	}
+}
+
+func TestVariant_64BitEncoding(t *testing.T) {
+	var count int64 = 1 << 40
+	var scale float64 = 1.0 / 3.0
+
+	var countBuf bytes.Buffer
+	if err := gdvariant.NewEncoder(&countBuf).Encode(count); err != nil {
+		t.Fatal(err)
+	}
+	if header := gdvariant.ReadHeaderFromBytes(countBuf.Bytes()[0:4]); header&gdvariant.EncodeFlag64 == 0 {
+		t.Errorf("expected EncodeFlag64 on int64 header, got %#x", header)
+	}
+	var gotCount gdvariant.Integer64
+	if err := gdvariant.NewDecoder(&countBuf).Decode(&gotCount); err != nil {
+		t.Fatal(err)
+	}
+	if int64(gotCount) != count {
+		t.Errorf("got count %d, exp %d", gotCount, count)
+	}
+
+	var scaleBuf bytes.Buffer
+	if err := gdvariant.NewEncoder(&scaleBuf).Encode(scale); err != nil {
+		t.Fatal(err)
+	}
+	if header := gdvariant.ReadHeaderFromBytes(scaleBuf.Bytes()[0:4]); header&gdvariant.EncodeFlag64 == 0 {
+		t.Errorf("expected EncodeFlag64 on float64 header, got %#x", header)
+	}
+	var gotScale gdvariant.Float64
+	if err := gdvariant.NewDecoder(&scaleBuf).Decode(&gotScale); err != nil {
+		t.Fatal(err)
+	}
+	if float64(gotScale) != scale {
+		t.Errorf("got scale %v, exp %v", gotScale, scale)
+	}
+}